@@ -0,0 +1,141 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// UnknownCode是查找不到已注册Coder时返回的哨兵错误码，
+// 也是ParseCoder在找不到匹配项时返回的错误码。
+const UnknownCode = 999999
+
+// Coder描述一个可注册的数字错误码，
+// 使本包可以作为HTTP/gRPC服务的错误基础设施使用：
+// 业务逻辑返回的任意错误都能被映射为状态码、机器可读的错误码、
+// 面向用户的提示信息（用于i18n）以及文档链接。
+type Coder interface {
+	// Code返回错误码的数字表示，用于注册和查找
+	Code() int
+	// HTTPStatus返回该错误码对应的HTTP状态码
+	HTTPStatus() int
+	// String返回面向用户的提示信息
+	String() string
+	// Reference返回该错误码的参考文档地址
+	Reference() string
+}
+
+type defaultCoder struct {
+	code       int
+	httpStatus int
+	message    string
+	reference  string
+}
+
+func (c defaultCoder) Code() int       { return c.code }
+func (c defaultCoder) HTTPStatus() int { return c.httpStatus }
+func (c defaultCoder) String() string  { return c.message }
+func (c defaultCoder) Reference() string {
+	return c.reference
+}
+
+var (
+	codersMu sync.RWMutex
+	coders   = map[int]Coder{
+		UnknownCode: defaultCoder{
+			code:       UnknownCode,
+			httpStatus: http.StatusInternalServerError,
+			message:    "internal server error",
+		},
+	}
+)
+
+//
+// 注册一个Coder，若该数字错误码已存在，将被覆盖。
+//
+// 参数
+// coder -- 待注册的Coder
+func Register(coder Coder) {
+	codersMu.Lock()
+	defer codersMu.Unlock()
+	coders[coder.Code()] = coder
+}
+
+//
+// 注册一个Coder，若该数字错误码已存在，将panic。
+// 用于在init阶段声明各模块独占的错误码，尽早暴露冲突。
+//
+// 参数
+// coder -- 待注册的Coder
+func MustRegister(coder Coder) {
+	codersMu.Lock()
+	defer codersMu.Unlock()
+	if _, ok := coders[coder.Code()]; ok {
+		panic(fmt.Sprintf("errors: coder already registered for code %d", coder.Code()))
+	}
+	coders[coder.Code()] = coder
+}
+
+//
+// 从一个错误中解析出其注册的Coder。
+// 若err不是本包的Error，或其数字错误码未注册，返回UnknownCode对应的Coder。
+//
+// 参数
+// err -- 任意类型的error实现
+//
+// 返回
+// 返回匹配到的Coder，err为nil时返回nil
+func ParseCoder(err error) Coder {
+	if err == nil {
+		return nil
+	}
+	impl, ok := ParseError(err).(*errImpl)
+	if !ok {
+		return lookupCoder(UnknownCode)
+	}
+	return lookupCoder(impl.data.NumCode)
+}
+
+func lookupCoder(code int) Coder {
+	codersMu.RLock()
+	defer codersMu.RUnlock()
+	if coder, ok := coders[code]; ok {
+		return coder
+	}
+	return coders[UnknownCode]
+}
+
+//
+// 使用已注册的数字错误码创建一个本包Error接口的实例，
+// 创建时即固化该错误码对应的HTTP状态码与文档链接，使其能跨进程传递，
+// 供HTTP中间件按Error()反解出的ErrData直接构建响应。
+// 若code未注册，落回UnknownCode对应的Coder。
+//
+// Go不支持按参数类型重载同名函数，因此数字code的入口是独立的NewWithCode，
+// 而不是让New(code string)变成同时接受string/int的New——New的签名保持不变。
+//
+// 参数
+// code -- 已注册的数字错误码
+// reason -- 错误的原因，通常是引起发生错误的参数，以便记录并还原出发生错误时的调用
+//
+// 返回
+// 返回Error实例
+func NewWithCode(code int, reason ...interface{}) Error {
+	coder := lookupCoder(code)
+	e := &errImpl{
+		data: ErrData{
+			Code:       coder.String(),
+			NumCode:    code,
+			HTTPStatus: coder.HTTPStatus(),
+			Reference:  coder.Reference(),
+			Reason:     [][]interface{}{{"init"}},
+			Where:      []string{caller(2)},
+		},
+		stack: captureStack(3),
+	}
+	if len(reason) > 0 {
+		e.data.Reason = append(e.data.Reason, reason)
+		e.data.Where = append(e.data.Where, caller(2))
+	}
+	return e
+}
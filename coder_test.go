@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+)
+
+type testCoder struct {
+	code int
+}
+
+func (c testCoder) Code() int       { return c.code }
+func (c testCoder) HTTPStatus() int { return http.StatusBadRequest }
+func (c testCoder) String() string  { return "bad request" }
+func (c testCoder) Reference() string {
+	return "https://example.com/errors/" + "bad-request"
+}
+
+func TestRegisterAndParseCoder(t *testing.T) {
+	const code = 100001
+	Register(testCoder{code: code})
+
+	err := NewWithCode(code, "reason")
+	coder := ParseCoder(err)
+	if coder.Code() != code {
+		t.Fatalf("want:%d,but:%d", code, coder.Code())
+	}
+	if coder.HTTPStatus() != http.StatusBadRequest {
+		t.Fatalf("want:%d,but:%d", http.StatusBadRequest, coder.HTTPStatus())
+	}
+
+	unknown := ParseCoder(NewWithCode(999888))
+	if unknown.Code() != UnknownCode {
+		t.Fatalf("want:%d,but:%d", UnknownCode, unknown.Code())
+	}
+}
+
+func TestMustRegisterPanicsOnDuplicate(t *testing.T) {
+	const code = 100002
+	MustRegister(testCoder{code: code})
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("want panic on duplicate MustRegister")
+		}
+	}()
+	MustRegister(testCoder{code: code})
+}
@@ -11,9 +11,9 @@
 //
 // func fn1(a int) error {
 //    if a == 1{
-//        return errors.ErrNoData.As(a)
+//        return errors.ErrNoData.Trace(a)
 //    }
-//    return errors.New("not implements").As(a)
+//    return errors.New("not implements").Trace(a)
 // }
 //
 // func fn2(b int) error {
@@ -36,6 +36,7 @@ package errors
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"runtime"
 	"strings"
 )
@@ -57,17 +58,36 @@ type Error interface {
 	// Add reason and caller positoin for error.
 	//
 	// Call as :
-	// err.As(reason)
+	// err.Trace(reason)
 	// or errors.As(err, reason)
 	//
 	// Append reason and return it self
-	As(arg ...interface{}) Error
+	Trace(reason ...interface{}) Error
 
 	// Compare error code
 	// Aall as :
 	// err.Equal(anotherErr)
 	// or errors.Equal(err)
 	Equal(err error) bool
+
+	// Return the wrapped underlying error, if any.
+	// Implements the Go 1.13+ errors.Unwrap bridge so that
+	// stderrors.Unwrap/Is/As can walk into errors produced by Wrap.
+	Unwrap() error
+
+	// Compare against target the same way Equal does, so that
+	// stderrors.Is(err, errors.ErrNoData) works without importing this package twice.
+	Is(target error) bool
+
+	// Populate target with this error when it is assignable, implementing
+	// the Go 1.13+ errors.As bridge (distinct from the package-level As,
+	// which appends a reason instead).
+	As(target interface{}) bool
+
+	// Return the call stack captured at New/Wrap time, innermost frame first.
+	// Empty when stack capture was disabled via SetStackEnabled, or after a
+	// cross-process round trip through Parse.
+	StackTrace() []Frame
 }
 
 //
@@ -97,17 +117,67 @@ func equal(err1 error, err2 error) bool {
 	}
 
 	eImpl1, eImpl2 := ParseError(err1), ParseError(err2)
-	return eImpl1.Code() == eImpl2.Code()
+	if eImpl1.Code() == eImpl2.Code() {
+		return true
+	}
+	// A MultiError (or an errImpl rebuilt from one via Parse) is equal to
+	// err2 when any of its recorded children is, so callers can test a
+	// combined error against a single sentinel without unwrapping first.
+	if impl1 := errImplOf(eImpl1); impl1 != nil {
+		for _, child := range impl1.data.Errors {
+			if child.Code == eImpl2.Code() {
+				return true
+			}
+		}
+	}
+	if impl2 := errImplOf(eImpl2); impl2 != nil {
+		for _, child := range impl2.data.Errors {
+			if child.Code == eImpl1.Code() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// errImplOf返回e背后的*errImpl，MultiError通过内嵌errImpl复用其实现，
+// 因此这里同时识别*errImpl与*MultiError两种动态类型。
+func errImplOf(e Error) *errImpl {
+	switch v := e.(type) {
+	case *errImpl:
+		return v
+	case *MultiError:
+		return v.errImpl
+	}
+	return nil
 }
 
 type ErrData struct {
 	Code   string          `json:"code"`
 	Reason [][]interface{} `json:"reason"`
 	Where  []string        `json:"where"`
+	Cause  *ErrData        `json:"cause,omitempty"`
+	// Errors记录MultiError聚合的子错误快照，供跨进程JSON传输后仍可通过
+	// Errors(err)还原出子错误列表。
+	Errors []ErrData `json:"errors,omitempty"`
+
+	// NumCode/HTTPStatus/Reference仅在通过NewWithCode创建时被填充，
+	// 使HTTP中间件可以直接从反序列化出的ErrData构建响应，无需重新查表。
+	NumCode    int    `json:"num_code,omitempty"`
+	HTTPStatus int    `json:"http_status,omitempty"`
+	Reference  string `json:"reference,omitempty"`
 }
 
 type errImpl struct {
 	data ErrData
+	// err caches the original wrapped error so Unwrap can return it as-is
+	// in-process; across process boundaries data.Cause carries the same
+	// information and is rebuilt into an *errImpl instead.
+	err error
+	// stack holds the raw program counters captured at New/Wrap time.
+	// It is unexported and not part of ErrData, so JSON transport keeps
+	// emitting only the compact Where list.
+	stack []uintptr
 }
 
 //
@@ -120,11 +190,12 @@ type errImpl struct {
 // 返回Error实例
 func New(code string) Error {
 	return &errImpl{
-		ErrData{
+		data: ErrData{
 			Code:   code,
 			Reason: [][]interface{}{{"init"}},
 			Where:  []string{caller(2)},
 		},
+		stack: captureStack(3),
 	}
 }
 
@@ -159,16 +230,50 @@ func ParseError(src error) Error {
 	if src == nil {
 		return nil
 	}
-	if e, ok := src.(*errImpl); ok {
+	if e, ok := src.(Error); ok {
 		return e
 	}
 	return parse(src.Error())
 }
 
+//
+// 用另一个错误包装出一个本包的Error实例，原错误将作为Cause被记录，
+// 使得stderrors.Unwrap/Is/As等标准库方法可以沿着Cause继续查找。
+//
+// 参数
+// err -- 被包装的原始错误，可以为nil
+// code -- 错误码或者文字描述，此值将用于Equal的比较
+// reason -- 错误的原因，通常是引起发生错误的参数，以便记录并还原出发生错误时的调用
+//
+// 返回
+// 返回Error实例
+func Wrap(err error, code string, reason ...interface{}) Error {
+	e := &errImpl{
+		data: ErrData{
+			Code:   code,
+			Reason: [][]interface{}{{"init"}},
+			Where:  []string{caller(2)},
+		},
+		stack: captureStack(3),
+	}
+	if err != nil {
+		e.err = err
+		if inner := errImplOf(ParseError(err)); inner != nil {
+			cause := inner.data
+			e.data.Cause = &cause
+		}
+	}
+	if len(reason) > 0 {
+		e.data.Reason = append(e.data.Reason, reason)
+		e.data.Where = append(e.data.Where, caller(2))
+	}
+	return e
+}
+
 //
 // 给一个错误构建错误定位信息
 // 解析error时等价于ParseError，并在解析出的Error后构建当前置的错误定位信息。
-// 若解析出的是本包的Error类型的实现，将在原实现基础上构建错误定位信息，此时也等价于Error的As方法调用。
+// 若解析出的是本包的Error类型的实现，将在原实现基础上构建错误定位信息，此时也等价于Error的Trace方法调用。
 //
 // 参数
 // err -- 任意类型的error实现
@@ -180,11 +285,12 @@ func As(err error, reason ...interface{}) Error {
 	if err == nil {
 		return nil
 	}
-	e := ParseError(err).(*errImpl)
-	e.data.Reason = append(e.data.Reason, reason)
-	e.data.Where = append(e.data.Where, caller(2))
+	e := ParseError(err)
+	if impl := errImplOf(e); impl != nil {
+		impl.data.Reason = append(impl.data.Reason, reason)
+		impl.data.Where = append(impl.data.Where, caller(2))
+	}
 	return e
-
 }
 
 func parse(src string) *errImpl {
@@ -199,7 +305,7 @@ func parse(src string) *errImpl {
 	if err := json.Unmarshal([]byte(src), &data); err != nil {
 		return New(src).(*errImpl)
 	}
-	return &errImpl{data}
+	return &errImpl{data: data}
 }
 
 // call for domain
@@ -239,7 +345,7 @@ func (e *errImpl) Code() string {
 
 // Error的Error方法实现
 func (e *errImpl) Error() string {
-	data, err := json.Marshal(e.data)
+	data, err := e.MarshalJSON()
 	if err != nil {
 		return fmt.Sprintf("%v", e.data)
 	}
@@ -247,8 +353,14 @@ func (e *errImpl) Error() string {
 }
 
 // Error的MarshalJson方法实现
+// 若设置了Redactor，脱敏在此处惰性生效，不影响内存中e.data本身，
+// 因此进程内调试时直接访问e.data仍能看到完整的原始值；%s/%v/%+v格式化输出不打印reason。
 func (e *errImpl) MarshalJSON() ([]byte, error) {
-	return json.Marshal(e.data)
+	r := currentRedactor()
+	if r == nil {
+		return json.Marshal(e.data)
+	}
+	return json.Marshal(redactErrData(r, e.data))
 }
 
 // Error的Equal方法实现
@@ -256,9 +368,48 @@ func (e *errImpl) Equal(l error) bool {
 	return equal(e, l)
 }
 
-// Error的As方法实现
-func (e *errImpl) As(reason ...interface{}) Error {
+// Error的Trace方法实现
+func (e *errImpl) Trace(reason ...interface{}) Error {
 	e.data.Reason = append(e.data.Reason, reason)
 	e.data.Where = append(e.data.Where, caller(2))
 	return e
 }
+
+// Error的Unwrap方法实现，用于桥接标准库errors.Unwrap
+func (e *errImpl) Unwrap() error {
+	if e.err != nil {
+		return e.err
+	}
+	if e.data.Cause != nil {
+		return &errImpl{data: *e.data.Cause}
+	}
+	return nil
+}
+
+// Error的Is方法实现，用于桥接标准库errors.Is，比较规则与Equal一致
+func (e *errImpl) Is(target error) bool {
+	if target == nil {
+		return e == nil
+	}
+	return equal(e, target)
+}
+
+// Error的As方法实现，用于桥接标准库errors.As，与包级的变长参数As不同
+func (e *errImpl) As(target interface{}) bool {
+	tVal := reflect.ValueOf(target)
+	if tVal.Kind() != reflect.Ptr || tVal.IsNil() {
+		return false
+	}
+	elem := tVal.Elem()
+	eVal := reflect.ValueOf(e)
+	switch {
+	case eVal.Type().AssignableTo(elem.Type()):
+		elem.Set(eVal)
+		return true
+	case elem.Kind() == reflect.Interface && eVal.Type().Implements(elem.Type()):
+		elem.Set(eVal)
+		return true
+	default:
+		return false
+	}
+}
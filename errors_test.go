@@ -31,7 +31,7 @@ func TestNew(t *testing.T) {
 	}
 
 	// way 3
-	eParseErr := ParseErr(e)
+	eParseErr := ParseError(e)
 	if eParseErr.Code() != code {
 		t.Fatalf("want:%s,but:%s", code, eParseErr.Code())
 		return
@@ -55,18 +55,18 @@ var equalTests = []struct {
 	out  bool
 }{
 	{New("New"), New("New"), true},
-	{New("New"), ParseErr(New("New")), true},
-	{New("New"), ParseErr(errors.New("New")), true},
+	{New("New"), ParseError(New("New")), true},
+	{New("New"), ParseError(errors.New("New")), true},
 	{New("New"), As(New("New")), true},
 	{New("New"), As(errors.New("New")), true},
 	{New("New"), As(New("New"), "reason"), true},
 	{New("New"), As(errors.New("New"), "reason"), true},
-	{ParseErr(New("ParseErr")), ParseErr(New("ParseErr")), true},
-	{ParseErr(New("ParseErr")), ParseErr(errors.New("ParseErr")), true},
-	{ParseErr(New("ParseErr")), As(New("ParseErr")), true},
-	{ParseErr(New("ParseErr")), As(errors.New("ParseErr")), true},
-	{ParseErr(New("ParseErr")), As(New("ParseErr"), "reason"), true},
-	{ParseErr(New("ParseErr")), As(errors.New("ParseErr"), "reason"), true},
+	{ParseError(New("ParseErr")), ParseError(New("ParseErr")), true},
+	{ParseError(New("ParseErr")), ParseError(errors.New("ParseErr")), true},
+	{ParseError(New("ParseErr")), As(New("ParseErr")), true},
+	{ParseError(New("ParseErr")), As(errors.New("ParseErr")), true},
+	{ParseError(New("ParseErr")), As(New("ParseErr"), "reason"), true},
+	{ParseError(New("ParseErr")), As(errors.New("ParseErr"), "reason"), true},
 }
 
 func TestEqual(t *testing.T) {
@@ -87,9 +87,9 @@ func TestAs(t *testing.T) {
 	err2 := New("test")
 
 	outErr1 := As(err1, "test", "test")
-	outErr2 := err2.As("test", "test")
+	outErr2 := err2.Trace("test", "test")
 	outErr3 := As(err1, 123, 456)
-	outErr4 := err2.As(123, 456)
+	outErr4 := err2.Trace(123, 456)
 	if len(outErr1.Error()) == 0 {
 		t.Fatal(outErr1)
 	}
@@ -109,9 +109,9 @@ func TestError(t *testing.T) {
 	err2 := New("test")
 
 	outErr1 := As(err1, "test", "test")
-	outErr2 := err2.As("test", "test")
+	outErr2 := err2.Trace("test", "test")
 	outErr3 := As(err1, 123, 456)
-	outErr4 := err2.As(123, 456)
+	outErr4 := err2.Trace(123, 456)
 	if len(outErr1.Error()) == 0 {
 		t.Fatal(outErr1)
 	}
@@ -126,5 +126,30 @@ func TestError(t *testing.T) {
 	}
 	fmt.Println(outErr4.Error())
 	fmt.Println(err1.(*errImpl))
-	fmt.Println(err1.As(err2))
+	fmt.Println(err1.Trace(err2))
+}
+
+func TestWrap(t *testing.T) {
+	cause := New("cause")
+	wrapped := Wrap(cause, "wrapped", "extra")
+
+	if !errors.Is(wrapped, cause) {
+		t.Fatalf("want errors.Is(wrapped, cause) true, but false")
+	}
+	if errors.Unwrap(wrapped).(Error).Code() != cause.Code() {
+		t.Fatalf("want:%s,but:%s", cause.Code(), errors.Unwrap(wrapped).(Error).Code())
+	}
+
+	var target Error
+	if !errors.As(wrapped, &target) {
+		t.Fatalf("want errors.As(wrapped, &target) true, but false")
+	}
+	if target.Code() != wrapped.Code() {
+		t.Fatalf("want:%s,but:%s", wrapped.Code(), target.Code())
+	}
+
+	roundTripped := Parse(wrapped.Error())
+	if errors.Unwrap(roundTripped).(Error).Code() != cause.Code() {
+		t.Fatalf("want:%s,but:%s", cause.Code(), errors.Unwrap(roundTripped).(Error).Code())
+	}
 }
\ No newline at end of file
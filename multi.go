@@ -0,0 +1,112 @@
+package errors
+
+//
+// MultiError按顺序聚合多个子错误，用于收集校验失败或并发goroutine中产生的
+// 一组错误，同时仍保留本包为每个子错误记录的code/reason/where信息。
+//
+// MultiError内嵌errImpl以复用Code/Trace/Error/MarshalJSON/StackTrace等实现。
+//
+// 本包Error接口要求Unwrap() error，与Go 1.20新增的Unwrap() []error签名不同，
+// 同一个具体类型无法同时提供两者（尝试过在MultiError上定义Unwrap() []error，
+// 会导致MultiError不再满足Error接口，errImplOf等处的*MultiError类型分支编译失败）。
+// 因此MultiError沿用errImpl的单一因果链Unwrap语义；stderrors.Is/Equal仍能
+// 按子错误逐个比较（equal对*MultiError/*errImpl的data.Errors做了特殊处理），
+// 需要真正按子错误fan-out遍历时改用Errors(err)。
+type MultiError struct {
+	*errImpl
+	errs []error
+}
+
+//
+// 将errs中的错误按顺序合并为一个error。
+// nil元素会被忽略；若某个元素本身是MultiError，会被展开而不是嵌套；
+// 合并后只剩0个则返回nil，只剩1个则原样返回该错误。
+//
+// 参数
+// errs -- 待合并的错误列表
+//
+// 返回
+// 返回合并后的error，可能为nil、单个错误或者*MultiError
+func Combine(errs ...error) error {
+	var filtered []error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if me, ok := err.(*MultiError); ok {
+			filtered = append(filtered, me.errs...)
+			continue
+		}
+		filtered = append(filtered, err)
+	}
+
+	switch len(filtered) {
+	case 0:
+		return nil
+	case 1:
+		return filtered[0]
+	}
+
+	base := New("multi_error").(*errImpl)
+	for _, err := range filtered {
+		base.data.Errors = append(base.data.Errors, snapshotData(err))
+	}
+	return &MultiError{errImpl: base, errs: filtered}
+}
+
+//
+// 将errs追加到dst上并合并为一个Error，语义等价于Combine(append([]error{dst}, errs...)...)，
+// 只是返回类型固定为本包的Error，方便直接链式调用Trace/Equal等方法。
+//
+// 参数
+// dst -- 已有的错误，可以为nil
+// errs -- 待追加的错误列表
+//
+// 返回
+// 返回合并后的Error，dst和errs全为nil时返回nil
+func Append(dst error, errs ...error) Error {
+	combined := Combine(append([]error{dst}, errs...)...)
+	if combined == nil {
+		return nil
+	}
+	return ParseError(combined)
+}
+
+//
+// 将err拆解为子错误列表。
+// 若err是*MultiError，返回其聚合的子错误（保留原始错误对象）；
+// 若err是经Parse从MultiError的JSON还原出来的普通Error，
+// 按其携带的Errors快照重建子错误列表；
+// 否则返回只包含err自身的单元素列表。
+//
+// 参数
+// err -- 任意类型的error实现，可以为nil
+//
+// 返回
+// 返回子错误列表，err为nil时返回nil
+func Errors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if me, ok := err.(*MultiError); ok {
+		out := make([]error, len(me.errs))
+		copy(out, me.errs)
+		return out
+	}
+	if impl := errImplOf(ParseError(err)); impl != nil && len(impl.data.Errors) > 0 {
+		out := make([]error, 0, len(impl.data.Errors))
+		for _, data := range impl.data.Errors {
+			out = append(out, &errImpl{data: data})
+		}
+		return out
+	}
+	return []error{err}
+}
+
+// snapshotData记录err此刻的ErrData快照，用于MultiError的JSON序列化。
+func snapshotData(err error) ErrData {
+	if impl := errImplOf(ParseError(err)); impl != nil {
+		return impl.data
+	}
+	return ErrData{Code: err.Error()}
+}
@@ -0,0 +1,109 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCombineAndErrors(t *testing.T) {
+	err1 := New("err1")
+	err2 := New("err2")
+
+	combined := Combine(nil, err1, nil, err2)
+	if combined == nil {
+		t.Fatal("want non-nil combined error")
+	}
+
+	children := Errors(combined)
+	if len(children) != 2 {
+		t.Fatalf("want 2 children, but:%d", len(children))
+	}
+	if !Equal(combined, err1) || !Equal(combined, err2) {
+		t.Fatalf("want combined to equal both children, but:%s", combined)
+	}
+
+	if Combine(nil, nil) != nil {
+		t.Fatal("want nil when combining only nils")
+	}
+	if got := Combine(err1); got != err1 {
+		t.Fatalf("want single error unwrapped as-is, but:%v", got)
+	}
+}
+
+func TestCombineRoundTrip(t *testing.T) {
+	err1 := New("err1")
+	err2 := New("err2")
+	combined := Combine(err1, err2)
+
+	roundTripped := Parse(combined.Error())
+	if !Equal(roundTripped, err1) || !Equal(roundTripped, err2) {
+		t.Fatalf("want round-tripped error to still equal both children, but:%s", roundTripped)
+	}
+	if len(Errors(roundTripped)) != 2 {
+		t.Fatalf("want 2 children after round trip, but:%d", len(Errors(roundTripped)))
+	}
+}
+
+// TestMultiErrorStdIsAs drives stdlib errors.Is/As directly against a
+// *MultiError, rather than only this package's own Equal/Errors helpers.
+// MultiError keeps errImpl's single-cause Unwrap() error (required by this
+// package's Error interface) instead of Go 1.20's Unwrap() []error, so
+// errors.Is relies on the Is bridge (backed by the same child-matching
+// equal() uses for Equal), and errors.As matches the MultiError itself;
+// per-child inspection goes through Errors(err).
+func TestMultiErrorStdIsAs(t *testing.T) {
+	err1 := New("err1")
+	err2 := New("err2")
+	combined := Combine(err1, err2)
+
+	if !errors.Is(combined, err1) {
+		t.Fatal("want stdlib errors.Is to match err1 via the Is bridge")
+	}
+	if !errors.Is(combined, err2) {
+		t.Fatal("want stdlib errors.Is to match err2 via the Is bridge")
+	}
+
+	var target Error
+	if !errors.As(combined, &target) {
+		t.Fatal("want stdlib errors.As to find an Error")
+	}
+	if _, ok := target.(*MultiError); !ok {
+		t.Fatalf("want As target to be the *MultiError itself, but:%T", target)
+	}
+	if !target.Equal(err1) || !target.Equal(err2) {
+		t.Fatalf("want As target to still equal both children, but:%s", target)
+	}
+}
+
+func TestWrapMultiError(t *testing.T) {
+	err1 := New("err1")
+	err2 := New("err2")
+	combined := Combine(err1, err2)
+
+	wrapped := Wrap(combined, "wrapped")
+	if !errors.Is(wrapped, err1) || !errors.Is(wrapped, err2) {
+		t.Fatalf("want wrapped to unwrap into both MultiError children, but:%s", wrapped)
+	}
+
+	roundTripped := Parse(wrapped.Error())
+	cause := roundTripped.(*errImpl).data.Cause
+	if cause == nil || len(cause.Errors) != 2 {
+		t.Fatalf("want round-tripped Cause to keep both MultiError children, but:%v", cause)
+	}
+	if !errors.Is(roundTripped, err1) || !errors.Is(roundTripped, err2) {
+		t.Fatalf("want round-tripped wrapped error to still unwrap into both children, but:%s", roundTripped)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	err1 := New("err1")
+	err2 := New("err2")
+
+	appended := Append(err1, err2)
+	if !appended.Equal(err2) {
+		t.Fatalf("want appended to equal err2, but:%s", appended)
+	}
+	if Append(nil) != nil {
+		t.Fatal("want nil when appending nothing to nil")
+	}
+}
@@ -0,0 +1,154 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+//
+// Redactor在错误序列化为JSON前对reason做脱敏处理。
+// As/Trace/Wrap等方法接受任意interface{}作为reason，可能带有PII、
+// token或较大的负载，而Error()返回的JSON本就用于跨进程传输，
+// 直接透传存在泄漏风险，因此提供该扩展点。
+type Redactor interface {
+	// Redact接收一个reason值，返回脱敏后可安全序列化的值
+	Redact(reason interface{}) interface{}
+}
+
+var (
+	redactorMu sync.RWMutex
+	redactor   Redactor
+)
+
+//
+// 设置全局Redactor，nil表示关闭脱敏（默认行为）。
+//
+// 参数
+// r -- 待生效的Redactor
+func SetRedactor(r Redactor) {
+	redactorMu.Lock()
+	defer redactorMu.Unlock()
+	redactor = r
+}
+
+func currentRedactor() Redactor {
+	redactorMu.RLock()
+	defer redactorMu.RUnlock()
+	return redactor
+}
+
+// defaultTruncateSize是TruncateRedactor未指定MaxSize时使用的默认值。
+const defaultTruncateSize = 256
+
+//
+// TruncateRedactor是内置的Redactor实现，
+// 将过长的字符串/切片截断到MaxSize，用于限制包文档中警告的
+// “比较大的数据量”问题。
+type TruncateRedactor struct {
+	MaxSize int
+}
+
+//
+// 创建一个TruncateRedactor，maxSize<=0时使用默认值defaultTruncateSize。
+//
+// 参数
+// maxSize -- 字符串/切片保留的最大长度
+//
+// 返回
+// 返回TruncateRedactor实例
+func NewTruncateRedactor(maxSize int) *TruncateRedactor {
+	if maxSize <= 0 {
+		maxSize = defaultTruncateSize
+	}
+	return &TruncateRedactor{MaxSize: maxSize}
+}
+
+// TruncateRedactor的Redact方法实现
+func (t *TruncateRedactor) Redact(reason interface{}) interface{} {
+	v := reflect.ValueOf(reason)
+	switch v.Kind() {
+	case reflect.String:
+		s := v.String()
+		if len(s) <= t.MaxSize {
+			return s
+		}
+		return fmt.Sprintf("%s...(truncated, len=%d)", s[:t.MaxSize], len(s))
+	case reflect.Slice:
+		if v.Len() <= t.MaxSize {
+			return reason
+		}
+		return fmt.Sprintf("%v...(truncated, len=%d)", v.Slice(0, t.MaxSize).Interface(), v.Len())
+	case reflect.Array:
+		if v.Len() <= t.MaxSize {
+			return reason
+		}
+		// Array值本身不可寻址，Slice()会panic，先拷贝到一个可寻址的切片。
+		sliceable := reflect.MakeSlice(reflect.SliceOf(v.Type().Elem()), v.Len(), v.Len())
+		reflect.Copy(sliceable, v)
+		return fmt.Sprintf("%v...(truncated, len=%d)", sliceable.Slice(0, t.MaxSize).Interface(), v.Len())
+	default:
+		return reason
+	}
+}
+
+// redactErrData递归地对一份ErrData快照（含Cause/Errors）应用r，
+// 返回脱敏后的副本，原始data不会被修改。
+func redactErrData(r Redactor, data ErrData) ErrData {
+	out := data
+	if len(data.Reason) > 0 {
+		out.Reason = make([][]interface{}, len(data.Reason))
+		for i, group := range data.Reason {
+			newGroup := make([]interface{}, len(group))
+			for j, item := range group {
+				newGroup[j] = redactReason(r, item)
+			}
+			out.Reason[i] = newGroup
+		}
+	}
+	if data.Cause != nil {
+		cause := redactErrData(r, *data.Cause)
+		out.Cause = &cause
+	}
+	if len(data.Errors) > 0 {
+		out.Errors = make([]ErrData, len(data.Errors))
+		for i, child := range data.Errors {
+			out.Errors[i] = redactErrData(r, child)
+		}
+	}
+	return out
+}
+
+// redactReason对单个reason值生效：若其为struct（或指向struct的指针），
+// 优先按errors:"redact"/errors:"omit"字段级tag处理；否则整体交给r.Redact。
+func redactReason(r Redactor, reason interface{}) interface{} {
+	v := reflect.ValueOf(reason)
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reason
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return r.Redact(reason)
+	}
+
+	t := v.Type()
+	out := make(map[string]interface{}, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// unexported field
+			continue
+		}
+		switch field.Tag.Get("errors") {
+		case "omit":
+			continue
+		case "redact":
+			out[field.Name] = r.Redact(v.Field(i).Interface())
+		default:
+			out[field.Name] = v.Field(i).Interface()
+		}
+	}
+	return out
+}
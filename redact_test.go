@@ -0,0 +1,60 @@
+package errors
+
+import (
+	"strings"
+	"testing"
+)
+
+type sensitivePayload struct {
+	UserID int
+	Token  string `errors:"redact"`
+	Secret string `errors:"omit"`
+}
+
+func TestRedactStructTags(t *testing.T) {
+	SetRedactor(NewTruncateRedactor(4))
+	defer SetRedactor(nil)
+
+	err := New("login failed").Trace(sensitivePayload{UserID: 1, Token: "abcdefgh", Secret: "s3cr3t"})
+	out := err.Error()
+
+	if strings.Contains(out, "s3cr3t") {
+		t.Fatalf("want Secret omitted, but:%s", out)
+	}
+	if strings.Contains(out, "abcdefgh") {
+		t.Fatalf("want Token truncated, but:%s", out)
+	}
+	if !strings.Contains(out, `"UserID":1`) {
+		t.Fatalf("want UserID untouched, but:%s", out)
+	}
+}
+
+func TestRedactTruncatesLongStrings(t *testing.T) {
+	SetRedactor(NewTruncateRedactor(4))
+	defer SetRedactor(nil)
+
+	err := New("too much data").Trace("0123456789")
+	out := err.Error()
+	if strings.Contains(out, "0123456789") {
+		t.Fatalf("want long reason truncated, but:%s", out)
+	}
+}
+
+func TestRedactTruncatesArrays(t *testing.T) {
+	SetRedactor(NewTruncateRedactor(4))
+	defer SetRedactor(nil)
+
+	key := [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	err := New("too much data").Trace(key)
+	out := err.Error()
+	if strings.Contains(out, "13") {
+		t.Fatalf("want array reason truncated, but:%s", out)
+	}
+}
+
+func TestRedactDisabledByDefault(t *testing.T) {
+	err := New("no redactor").Trace("0123456789")
+	if !strings.Contains(err.Error(), "0123456789") {
+		t.Fatalf("want full reason without a redactor, but:%s", err.Error())
+	}
+}
@@ -0,0 +1,149 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+var (
+	stackMu sync.RWMutex
+	// stackDepth限制单次capture的最大帧数，与SetStackDepth配合使用。
+	stackDepth = 32
+	// stackEnabled控制是否在New/Wrap时捕获调用栈，关闭以避免文档中警告的性能开销。
+	stackEnabled = true
+)
+
+//
+// 设置每次捕获调用栈时记录的最大帧数，depth<=0时忽略。
+//
+// 参数
+// depth -- 最大帧数
+func SetStackDepth(depth int) {
+	if depth <= 0 {
+		return
+	}
+	stackMu.Lock()
+	defer stackMu.Unlock()
+	stackDepth = depth
+}
+
+//
+// 设置是否在New/Wrap时捕获完整调用栈。
+// 因捕获调用栈存在一定的性能开销，可在对性能敏感的场景关闭。
+//
+// 参数
+// enabled -- 是否开启
+func SetStackEnabled(enabled bool) {
+	stackMu.Lock()
+	defer stackMu.Unlock()
+	stackEnabled = enabled
+}
+
+// Frame表示调用栈中的一帧。
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+	PC       uintptr
+}
+
+// Frame的String实现，格式与caller()保持一致，方便阅读。
+func (f Frame) String() string {
+	return fmt.Sprintf("%s(%s:%d)", f.Function, f.File, f.Line)
+}
+
+// captureStack在New/Wrap时捕获调用栈的原始PC，按stackEnabled/stackDepth生效。
+func captureStack(skip int) []uintptr {
+	stackMu.RLock()
+	enabled, depth := stackEnabled, stackDepth
+	stackMu.RUnlock()
+	if !enabled {
+		return nil
+	}
+	pcs := make([]uintptr, depth)
+	n := runtime.Callers(skip, pcs)
+	return pcs[:n]
+}
+
+//
+// 返回错误创建/包装时刻捕获的调用栈。
+// 若创建时关闭了栈捕获，或该错误是跨进程反序列化得到的，返回nil。
+//
+// 返回
+// 返回调用栈帧列表，由内向外排列
+func (e *errImpl) StackTrace() []Frame {
+	if len(e.stack) == 0 {
+		return nil
+	}
+	frames := runtime.CallersFrames(e.stack)
+	result := make([]Frame, 0, len(e.stack))
+	for {
+		frame, more := frames.Next()
+		result = append(result, Frame{
+			Function: shortName(frame.Function),
+			File:     shortName(frame.File),
+			Line:     frame.Line,
+			PC:       frame.PC,
+		})
+		if !more {
+			break
+		}
+	}
+	return result
+}
+
+// Format实现fmt.Formatter接口。
+// %s   打印错误码
+// %v   打印错误码及最内层调用帧（即错误创建处）
+// %+v  打印错误码、完整调用栈，并递归打印被包装的错误
+// %q   打印Error()序列化出的JSON文本
+func (e *errImpl) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			fmt.Fprint(f, e.verboseString())
+			return
+		}
+		fmt.Fprint(f, e.shortString())
+	case 's':
+		fmt.Fprint(f, e.Code())
+	case 'q':
+		fmt.Fprintf(f, "%q", e.Error())
+	default:
+		fmt.Fprint(f, e.Code())
+	}
+}
+
+func (e *errImpl) shortString() string {
+	frames := e.StackTrace()
+	if len(frames) == 0 {
+		return e.Code()
+	}
+	return fmt.Sprintf("%s %s", e.Code(), frames[0])
+}
+
+func (e *errImpl) verboseString() string {
+	var b strings.Builder
+	b.WriteString(e.Code())
+	for _, frame := range e.StackTrace() {
+		b.WriteString("\n\t")
+		b.WriteString(frame.String())
+	}
+	if cause := e.Unwrap(); cause != nil {
+		b.WriteString("\nCaused by: ")
+		if inner, ok := cause.(*errImpl); ok {
+			b.WriteString(inner.verboseString())
+		} else {
+			b.WriteString(cause.Error())
+		}
+	}
+	return b.String()
+}
+
+// shortName截取包路径中最后一段，与caller()中的处理规则保持一致。
+func shortName(s string) string {
+	fields := strings.Split(s, "/")
+	return fields[len(fields)-1]
+}
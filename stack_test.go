@@ -0,0 +1,65 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestStackTrace(t *testing.T) {
+	err := New("stack test")
+	frames := err.StackTrace()
+	if len(frames) == 0 {
+		t.Fatal("want non-empty stack trace")
+	}
+	if !strings.Contains(frames[0].Function, "TestStackTrace") {
+		t.Fatalf("want top frame in TestStackTrace, but:%s", frames[0].Function)
+	}
+
+	SetStackEnabled(false)
+	defer SetStackEnabled(true)
+	disabled := New("stack disabled")
+	if len(disabled.StackTrace()) != 0 {
+		t.Fatal("want empty stack trace when disabled")
+	}
+}
+
+func TestStackConcurrentAccess(t *testing.T) {
+	defer SetStackEnabled(true)
+	defer SetStackDepth(32)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		i := i
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetStackEnabled(i%2 == 0)
+			SetStackDepth(i + 1)
+		}()
+		go func() {
+			defer wg.Done()
+			New("concurrent")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestFormat(t *testing.T) {
+	cause := New("cause")
+	err := Wrap(cause, "wrapped")
+
+	if got := fmt.Sprintf("%s", err); got != "wrapped" {
+		t.Fatalf("want:%s,but:%s", "wrapped", got)
+	}
+	if got := fmt.Sprintf("%v", err); !strings.HasPrefix(got, "wrapped ") {
+		t.Fatalf("want prefix %q, but:%s", "wrapped ", got)
+	}
+	if got := fmt.Sprintf("%+v", err); !strings.Contains(got, "Caused by: cause") {
+		t.Fatalf("want stack to contain cause, but:%s", got)
+	}
+	if got := fmt.Sprintf("%q", err); !strings.HasPrefix(got, `"{`) {
+		t.Fatalf("want JSON blob quoted, but:%s", got)
+	}
+}